@@ -0,0 +1,113 @@
+// Copyright 2020 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "github.com/mongodb/go-client-mongodb-atlas/mongodbatlas"
+)
+
+const agentsBasePath = "groups/%s/agents"
+
+// AgentsService is an interface for interfacing with the Agents
+// endpoints of the MongoDB Ops Manager API.
+// See more: https://docs.opsmanager.mongodb.com/current/reference/api/agents/
+type AgentsService interface {
+	ListAgentLinks(context.Context, string) (*Agents, *atlas.Response, error)
+	ListAgentsByType(context.Context, string, string) (*Agents, *atlas.Response, error)
+}
+
+// AgentsServiceOp handles communication with the Agents related methods of
+// the OpsManager API.
+type AgentsServiceOp service
+
+var _ AgentsService = &AgentsServiceOp{}
+
+// Agents holds a page of agents, or the links available for a group.
+type Agents struct {
+	Links      []*atlas.Link `json:"links,omitempty"`
+	Results    []*Agent      `json:"results,omitempty"`
+	TotalCount int           `json:"totalCount,omitempty"`
+}
+
+// Agent represents a single agent registered against a group.
+type Agent struct {
+	TypeName  string `json:"typeName,omitempty"`
+	Hostname  string `json:"hostname,omitempty"`
+	ConfCount int    `json:"confCount,omitempty"`
+	LastConf  string `json:"lastConf,omitempty"`
+	StateName string `json:"stateName,omitempty"`
+	PingCount int    `json:"pingCount,omitempty"`
+	IsManaged bool   `json:"isManaged,omitempty"`
+	LastPing  string `json:"lastPing,omitempty"`
+}
+
+// ListAgentLinks lists the links to the different agent types registered for
+// a group.
+// See more: https://docs.opsmanager.mongodb.com/current/reference/api/agents/
+func (s *AgentsServiceOp) ListAgentLinks(ctx context.Context, groupID string) (*Agents, *atlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupID", "must be set")
+	}
+	ctx = withServiceName(ctx, "Agents")
+
+	path := fmt.Sprintf(agentsBasePath, groupID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(Agents)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, nil
+}
+
+// ListAgentsByType lists the agents of a given type (MONITORING, BACKUP, or
+// AUTOMATION) registered for a group, following every page of results via
+// PaginateAll instead of hand-rolling the page loop here. The returned
+// *atlas.Response is that of the last page fetched.
+// See more: https://docs.opsmanager.mongodb.com/current/reference/api/agents/
+func (s *AgentsServiceOp) ListAgentsByType(ctx context.Context, groupID, agentType string) (*Agents, *atlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupID", "must be set")
+	}
+	if agentType == "" {
+		return nil, nil, atlas.NewArgError("agentType", "must be set")
+	}
+	ctx = withServiceName(ctx, "Agents")
+
+	path := fmt.Sprintf("%s/%s", fmt.Sprintf(agentsBasePath, groupID), agentType)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := &Agents{}
+	resp, err := s.Client.PaginateAll(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "github.com/mongodb/go-client-mongodb-atlas/mongodbatlas"
+)
+
+const alertsBasePath = "groups/%s/alerts"
+
+// AlertsService is an interface for interfacing with the Alerts endpoints of
+// the MongoDB Ops Manager API.
+// See more: https://docs.opsmanager.mongodb.com/current/reference/api/alerts/
+type AlertsService interface {
+	List(context.Context, string) (*Alerts, *atlas.Response, error)
+}
+
+// AlertsServiceOp handles communication with the Alerts related methods of
+// the OpsManager API.
+type AlertsServiceOp service
+
+var _ AlertsService = &AlertsServiceOp{}
+
+// Alerts holds a page of alerts for a group.
+type Alerts struct {
+	Links      []*atlas.Link `json:"links,omitempty"`
+	Results    []*Alert      `json:"results,omitempty"`
+	TotalCount int           `json:"totalCount,omitempty"`
+}
+
+// Alert represents a single alert raised for a group.
+type Alert struct {
+	ID            string `json:"id,omitempty"`
+	GroupID       string `json:"groupId,omitempty"`
+	AlertConfigID string `json:"alertConfigId,omitempty"`
+	EventTypeName string `json:"eventTypeName,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Created       string `json:"created,omitempty"`
+}
+
+// List lists every alert raised for a group, following every page of
+// results via PaginateAll instead of hand-rolling the page loop here. The
+// returned *atlas.Response is that of the last page fetched.
+// See more: https://docs.opsmanager.mongodb.com/current/reference/api/alerts/
+func (s *AlertsServiceOp) List(ctx context.Context, groupID string) (*Alerts, *atlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupID", "must be set")
+	}
+	ctx = withServiceName(ctx, "Alerts")
+
+	path := fmt.Sprintf(alertsBasePath, groupID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := &Alerts{}
+	resp, err := s.Client.PaginateAll(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDigestTransport_RoundTrip(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Digest ") {
+			w.Header().Set("WWW-Authenticate", `Digest realm="OpsManager", qop="auth", algorithm=MD5, nonce="abc123"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !strings.Contains(auth, `username="admin"`) {
+			t.Errorf("Authorization header missing username: %s", auth)
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	client, err := New(&http.Client{}, SetBaseURL(server.URL+"/"), SetDigestAuth("admin", "apiKey"))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodGet, "groups", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var v map[string]interface{}
+	if _, err := client.Do(ctx, req, &v); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected 2 requests (challenge + authorized retry), got %d", requests)
+	}
+
+	// A second request against the same host should reuse the cached
+	// challenge instead of paying another 401 round-trip.
+	req2, err := client.NewRequest(ctx, http.MethodGet, "groups", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(ctx, req2, &v); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if requests != 3 {
+		t.Fatalf("expected the cached challenge to be reused, got %d total requests", requests)
+	}
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	header := `Digest realm="OpsManager", qop="auth", nonce="abc123", opaque="xyz", algorithm=SHA-256`
+
+	challenge, err := parseDigestChallenge(header)
+	if err != nil {
+		t.Fatalf("parseDigestChallenge returned error: %v", err)
+	}
+
+	if challenge.realm != "OpsManager" || challenge.nonce != "abc123" || challenge.opaque != "xyz" || challenge.qop != "auth" || challenge.algorithm != "SHA-256" {
+		t.Errorf("unexpected challenge: %+v", challenge)
+	}
+}
+
+func TestParseDigestChallenge_NotDigest(t *testing.T) {
+	if _, err := parseDigestChallenge(`Basic realm="OpsManager"`); err == nil {
+		t.Error("expected an error for a non-Digest scheme")
+	}
+}
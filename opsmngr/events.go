@@ -0,0 +1,79 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	atlas "github.com/mongodb/go-client-mongodb-atlas/mongodbatlas"
+)
+
+const eventsBasePath = "groups/%s/events"
+
+// EventsService is an interface for interfacing with the Events endpoints of
+// the MongoDB Ops Manager API.
+// See more: https://docs.opsmanager.mongodb.com/current/reference/api/events/
+type EventsService interface {
+	List(context.Context, string) (*Events, *atlas.Response, error)
+}
+
+// EventsServiceOp handles communication with the Events related methods of
+// the OpsManager API.
+type EventsServiceOp service
+
+var _ EventsService = &EventsServiceOp{}
+
+// Events holds a page of events for a group.
+type Events struct {
+	Links      []*atlas.Link `json:"links,omitempty"`
+	Results    []*Event      `json:"results,omitempty"`
+	TotalCount int           `json:"totalCount,omitempty"`
+}
+
+// Event represents a single event raised for a group.
+type Event struct {
+	ID            string `json:"id,omitempty"`
+	GroupID       string `json:"groupId,omitempty"`
+	EventTypeName string `json:"eventTypeName,omitempty"`
+	Created       string `json:"created,omitempty"`
+}
+
+// List lists every event raised for a group, following every page of
+// results via PaginateAll instead of hand-rolling the page loop here. The
+// returned *atlas.Response is that of the last page fetched.
+// See more: https://docs.opsmanager.mongodb.com/current/reference/api/events/
+func (s *EventsServiceOp) List(ctx context.Context, groupID string) (*Events, *atlas.Response, error) {
+	if groupID == "" {
+		return nil, nil, atlas.NewArgError("groupID", "must be set")
+	}
+	ctx = withServiceName(ctx, "Events")
+
+	path := fmt.Sprintf(eventsBasePath, groupID)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := &Events{}
+	resp, err := s.Client.PaginateAll(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, nil
+}
@@ -28,9 +28,16 @@ import (
 	"reflect"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/google/go-querystring/query"
 	atlas "github.com/mongodb/go-client-mongodb-atlas/mongodbatlas"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -55,10 +62,10 @@ type Client struct {
 	Automation            AutomationService
 	UnauthUsers           UnauthUsersService
 	AlertConfigurations   atlas.AlertConfigurationsService
-	Alerts                atlas.AlertsService
+	Alerts                AlertsService
 	ContinuousSnapshots   atlas.ContinuousSnapshotsService
 	ContinuousRestoreJobs atlas.ContinuousRestoreJobsService
-	Events                atlas.EventsService
+	Events                EventsService
 	Agents                AgentsService
 	Checkpoints           CheckpointsService
 	GlobalAlerts          GlobalAlertsService
@@ -70,16 +77,25 @@ type Client struct {
 	Diagnostics           DiagnosticsService
 
 	onRequestCompleted atlas.RequestCompletionCallback
+
+	tracer          trace.Tracer
+	requestDuration metric.Float64Histogram
+	requestCount    metric.Int64Counter
 }
 
+// service is embedded by the locally-defined *ServiceOp types (as opposed to
+// the ones provided by the atlas package) so they get direct access to the
+// full Client, including helpers like Paginate that aren't part of the
+// narrower atlas.RequestDoer interface.
 type service struct {
-	Client atlas.RequestDoer
+	Client *Client
 }
 
 // NewClient returns a new Ops Manager API client. If a nil httpClient is
 // provided, a http.DefaultClient will be used. To use API methods which require
 // authentication, provide an http.Client that will perform the authentication
-// for you (such as that provided by the github.com/Sectorbob/mlab-ns2/gae/ns/digest).
+// for you, or use New with SetDigestAuth (or NewDigestClient) for HTTP Digest
+// authentication.
 func NewClient(httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
@@ -102,9 +118,9 @@ func NewClient(httpClient *http.Client) *Client {
 	c.ContinuousRestoreJobs = &atlas.ContinuousRestoreJobsServiceOp{Client: c}
 	c.Agents = &AgentsServiceOp{Client: c}
 	c.Checkpoints = &CheckpointsServiceOp{Client: c}
-	c.Alerts = &atlas.AlertsServiceOp{Client: c}
+	c.Alerts = &AlertsServiceOp{Client: c}
 	c.GlobalAlerts = &GlobalAlertsServiceOp{Client: c}
-	c.Events = &atlas.EventsServiceOp{Client: c}
+	c.Events = &EventsServiceOp{Client: c}
 	c.Deployments = &DeploymentsServiceOp{Client: c}
 	c.Measurements = &MeasurementsServiceOp{Client: c}
 	c.Clusters = &ClustersServiceOp{Client: c}
@@ -274,30 +290,70 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*atl
 		return nil, errors.New("context must be non-nil")
 	}
 
+	start := time.Now()
+
+	var span trace.Span
+	if c.tracer != nil {
+		ctx, span = c.tracer.Start(ctx, "opsmngr.Do", trace.WithSpanKind(trace.SpanKindClient))
+		defer span.End()
+
+		span.SetAttributes(
+			attribute.String("http.method", req.Method),
+			attribute.String("http.url", req.URL.String()),
+		)
+		if svc := serviceNameFromContext(ctx); svc != "" {
+			span.SetAttributes(attribute.String("opsmngr.service", svc))
+		}
+		if projectID, ok := pathParam(req.URL.Path, "groups"); ok {
+			span.SetAttributes(attribute.String("opsmngr.project_id", projectID))
+		}
+		if orgID, ok := pathParam(req.URL.Path, "orgs"); ok {
+			span.SetAttributes(attribute.String("opsmngr.org_id", orgID))
+		}
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	}
+
 	req = req.WithContext(ctx)
 
 	resp, err := c.client.Do(req)
+	defer c.recordRequestMetrics(ctx, req, resp, start)
+
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			err = ctx.Err()
 		default:
 		}
 
+		if span != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
 		return nil, err
 	}
 	if c.onRequestCompleted != nil {
 		c.onRequestCompleted(req, resp)
 	}
 
+	if span != nil {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+
 	defer resp.Body.Close()
 
 	response := &atlas.Response{Response: resp}
 
 	err = atlas.CheckResponse(resp)
 	if err != nil {
+		if span != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
 		return response, err
 	}
 
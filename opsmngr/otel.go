@@ -0,0 +1,131 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName          = "go.mongodb.org/ops-manager/opsmngr"
+	requestDurationInstrument    = "opsmngr.client.request.duration"
+	requestCountInstrument       = "opsmngr.client.requests"
+	requestDurationInstrumentDoc = "Duration of Ops Manager API requests, in seconds"
+	requestCountInstrumentDoc    = "Number of Ops Manager API requests, partitioned by status class"
+)
+
+// SetTracerProvider is a client option that makes Client.Do emit an
+// OpenTelemetry span for every API call. When this option isn't used, Do
+// never touches the tracing API, so there is zero overhead by default.
+func SetTracerProvider(tp trace.TracerProvider) ClientOpt {
+	return func(c *Client) error {
+		c.tracer = tp.Tracer(instrumentationName)
+		return nil
+	}
+}
+
+// SetMeterProvider is a client option that makes Client.Do record a
+// opsmngr.client.request.duration histogram and a opsmngr.client.requests
+// counter, both partitioned by HTTP method and status class. When this
+// option isn't used, Do never touches the metrics API, so there is zero
+// overhead by default.
+func SetMeterProvider(mp metric.MeterProvider) ClientOpt {
+	return func(c *Client) error {
+		meter := mp.Meter(instrumentationName)
+
+		duration, err := meter.Float64Histogram(requestDurationInstrument, metric.WithDescription(requestDurationInstrumentDoc))
+		if err != nil {
+			return err
+		}
+		count, err := meter.Int64Counter(requestCountInstrument, metric.WithDescription(requestCountInstrumentDoc))
+		if err != nil {
+			return err
+		}
+
+		c.requestDuration = duration
+		c.requestCount = count
+		return nil
+	}
+}
+
+func (c *Client) recordRequestMetrics(ctx context.Context, req *http.Request, resp *http.Response, start time.Time) {
+	if c.requestDuration == nil && c.requestCount == nil {
+		return
+	}
+
+	statusClass := "error"
+	if resp != nil {
+		statusClass = fmt.Sprintf("%dxx", resp.StatusCode/100)
+	}
+
+	attrs := metric.WithAttributes(
+		attribute.String("http.method", req.Method),
+		attribute.String("http.status_class", statusClass),
+	)
+
+	if c.requestDuration != nil {
+		c.requestDuration.Record(ctx, time.Since(start).Seconds(), attrs)
+	}
+	if c.requestCount != nil {
+		c.requestCount.Add(ctx, 1, attrs)
+	}
+}
+
+type serviceNameKey struct{}
+
+// withServiceName returns a copy of ctx tagged with the name of the service
+// (e.g. "Agents") issuing the request, so Client.Do can attach it to a span
+// as opsmngr.service. The Agents, Alerts and Events service methods set this
+// on their ctx before calling NewRequest/Do/PaginateAll; requests issued by
+// the other services carry no service name, and their spans have no
+// opsmngr.service attribute. The name travels on ctx because a request can
+// reach Do indirectly (e.g. via Paginator.Next), where walking the call stack
+// to infer the caller would give the wrong answer.
+func withServiceName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, serviceNameKey{}, name)
+}
+
+func serviceNameFromContext(ctx context.Context) string {
+	name, _ := ctx.Value(serviceNameKey{}).(string)
+	return name
+}
+
+var pathParamRE = map[string]*regexp.Regexp{
+	"groups": regexp.MustCompile(`/groups/([^/]+)`),
+	"orgs":   regexp.MustCompile(`/orgs/([^/]+)`),
+}
+
+// pathParam extracts the identifier that follows segment (e.g. "groups" or
+// "orgs") in an Ops Manager API path, such as the projectID in
+// /api/public/v1.0/groups/{projectID}/agents.
+func pathParam(path, segment string) (string, bool) {
+	re, ok := pathParamRE[segment]
+	if !ok {
+		return "", false
+	}
+	m := re.FindStringSubmatch(path)
+	if len(m) != 2 {
+		return "", false
+	}
+	return m[1], true
+}
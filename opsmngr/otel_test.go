@@ -0,0 +1,53 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPathParam(t *testing.T) {
+	tests := []struct {
+		path    string
+		segment string
+		want    string
+		wantOK  bool
+	}{
+		{"/api/public/v1.0/groups/5e66185d917b220fbd8bb4d1/agents", "groups", "5e66185d917b220fbd8bb4d1", true},
+		{"/api/public/v1.0/orgs/5e66185d917b220fbd8bb4d1/users", "orgs", "5e66185d917b220fbd8bb4d1", true},
+		{"/api/public/v1.0/unauthUsers", "groups", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := pathParam(tt.path, tt.segment)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("pathParam(%q, %q) = (%q, %v), want (%q, %v)", tt.path, tt.segment, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestServiceNameFromContext(t *testing.T) {
+	ctx := context.Background()
+
+	if got := serviceNameFromContext(ctx); got != "" {
+		t.Errorf("serviceNameFromContext(ctx) = %q, want %q", got, "")
+	}
+
+	ctx = withServiceName(ctx, "Agents")
+	if got := serviceNameFromContext(ctx); got != "Agents" {
+		t.Errorf("serviceNameFromContext(ctx) = %q, want %q", got, "Agents")
+	}
+}
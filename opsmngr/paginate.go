@@ -0,0 +1,213 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+
+	atlas "github.com/mongodb/go-client-mongodb-atlas/mongodbatlas"
+)
+
+// Paginator streams the pages of a list endpoint, following the "next" link
+// in the Links returned by Ops Manager instead of requiring every service to
+// hand-roll its own loop over atlas.ListOptions. Because it goes through
+// Client.Do, a paginated crawl automatically benefits from a retry transport
+// installed with SetRetry.
+//
+// AgentsServiceOp.ListAgentsByType, EventsServiceOp.List, and
+// AlertsServiceOp.List all use this internally, via PaginateAll.
+type Paginator struct {
+	client   *Client
+	ctx      context.Context
+	method   string
+	nextURL  string
+	pageType reflect.Type
+	page     interface{}
+	resp     *atlas.Response
+	err      error
+	done     bool
+}
+
+// Paginate returns a Paginator for req. out is only used to determine the
+// page type: each page is decoded into a freshly allocated value of the same
+// type that out points to.
+func (c *Client) Paginate(ctx context.Context, req *http.Request, out interface{}) *Paginator {
+	t := reflect.TypeOf(out)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return &Paginator{
+		client:   c,
+		ctx:      ctx,
+		method:   req.Method,
+		nextURL:  req.URL.String(),
+		pageType: t,
+	}
+}
+
+// Next fetches the next page, if any, and reports whether one was fetched.
+// It returns false once the last page has been fetched or a request fails;
+// call Err to distinguish the two.
+func (p *Paginator) Next() bool {
+	if p.done || p.nextURL == "" {
+		p.done = true
+		return false
+	}
+
+	req, err := p.client.NewRequest(p.ctx, p.method, p.nextURL, nil)
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+
+	page := reflect.New(p.pageType).Interface()
+	resp, err := p.client.Do(p.ctx, req, page)
+	if resp != nil {
+		p.resp = resp
+	}
+	if err != nil {
+		p.err = err
+		p.done = true
+		return false
+	}
+
+	p.page = page
+	p.nextURL = nextPageURL(page)
+	return true
+}
+
+// Page returns the page decoded by the most recent call to Next.
+func (p *Paginator) Page() interface{} {
+	return p.page
+}
+
+// Response returns the most recent *atlas.Response the Paginator received, or
+// nil if no request has produced a response yet.
+func (p *Paginator) Response() *atlas.Response {
+	return p.resp
+}
+
+// Err returns the first error encountered while paginating, if any.
+func (p *Paginator) Err() error {
+	return p.err
+}
+
+// All drains the paginator, appending every page's Results into dst, which
+// must be a pointer to a slice. It stops at the first error.
+func (p *Paginator) All(ctx context.Context, dst interface{}) error {
+	p.ctx = ctx
+
+	dstVal := reflect.ValueOf(dst)
+	if dstVal.Kind() != reflect.Ptr || dstVal.Elem().Kind() != reflect.Slice {
+		return errors.New("opsmngr: All requires a pointer to a slice")
+	}
+	slice := dstVal.Elem()
+
+	for p.Next() {
+		results, err := pageResults(p.Page())
+		if err != nil {
+			return err
+		}
+		slice = reflect.AppendSlice(slice, results)
+	}
+	if err := p.Err(); err != nil {
+		return err
+	}
+
+	dstVal.Elem().Set(slice)
+	return nil
+}
+
+// PaginateAll drains every page of req into root, decoding each page as the
+// same type root points to, and accumulating each page's Results field into
+// root.Results while keeping the Links and TotalCount of the first page.
+// root must point to a struct shaped like Agents/Events/Alerts: a Links
+// field, a Results slice, and a TotalCount field. It returns the
+// *atlas.Response of the last page fetched, which is nil only if the very
+// first request failed.
+func (c *Client) PaginateAll(ctx context.Context, req *http.Request, root interface{}) (*atlas.Response, error) {
+	p := c.Paginate(ctx, req, root)
+
+	rootVal := reflect.ValueOf(root).Elem()
+	linksField := rootVal.FieldByName("Links")
+	resultsField := rootVal.FieldByName("Results")
+	totalField := rootVal.FieldByName("TotalCount")
+
+	first := true
+	for p.Next() {
+		pageVal := reflect.ValueOf(p.Page()).Elem()
+		if first {
+			if linksField.IsValid() {
+				linksField.Set(pageVal.FieldByName("Links"))
+			}
+			if totalField.IsValid() {
+				totalField.Set(pageVal.FieldByName("TotalCount"))
+			}
+			first = false
+		}
+		if resultsField.IsValid() {
+			resultsField.Set(reflect.AppendSlice(resultsField, pageVal.FieldByName("Results")))
+		}
+	}
+
+	return p.Response(), p.Err()
+}
+
+func pageResults(page interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(page)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, errors.New("opsmngr: page is not a struct")
+	}
+
+	results := v.FieldByName("Results")
+	if !results.IsValid() || results.Kind() != reflect.Slice {
+		return reflect.Value{}, errors.New("opsmngr: page type has no Results slice")
+	}
+	return results, nil
+}
+
+func nextPageURL(page interface{}) string {
+	v := reflect.ValueOf(page)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+
+	f := v.FieldByName("Links")
+	if !f.IsValid() {
+		return ""
+	}
+
+	links, ok := f.Interface().([]*atlas.Link)
+	if !ok {
+		return ""
+	}
+	for _, l := range links {
+		if l != nil && l.Rel == "next" {
+			return l.Href
+		}
+	}
+	return ""
+}
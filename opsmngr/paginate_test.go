@@ -0,0 +1,71 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	atlas "github.com/mongodb/go-client-mongodb-atlas/mongodbatlas"
+)
+
+func TestPaginator_All(t *testing.T) {
+	client, mux, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/groups/1/agents/MONITORING", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageNum") == "2" {
+			fmt.Fprint(w, `{"links":[],"results":[{"hostname":"host-2"}],"totalCount":2}`)
+			return
+		}
+		fmt.Fprintf(w, `{"links":[{"rel":"next","href":"%sgroups/1/agents/MONITORING?pageNum=2"}],"results":[{"hostname":"host-1"}],"totalCount":2}`, client.BaseURL.String())
+	})
+
+	req, err := client.NewRequest(ctx, http.MethodGet, "groups/1/agents/MONITORING", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var agents []*Agent
+	p := client.Paginate(ctx, req, &Agents{})
+	if err := p.All(ctx, &agents); err != nil {
+		t.Fatalf("All returned error: %v", err)
+	}
+
+	if len(agents) != 2 {
+		t.Fatalf("expected 2 agents, got %d", len(agents))
+	}
+	if agents[0].Hostname != "host-1" || agents[1].Hostname != "host-2" {
+		t.Errorf("unexpected agents: %+v %+v", agents[0], agents[1])
+	}
+}
+
+func TestNextPageURL(t *testing.T) {
+	page := &Agents{
+		Links: []*atlas.Link{
+			{Rel: "self", Href: "https://cloud.mongodb.com/api/public/v1.0/groups/1/agents"},
+			{Rel: "next", Href: "https://cloud.mongodb.com/api/public/v1.0/groups/1/agents?pageNum=2"},
+		},
+	}
+
+	if got := nextPageURL(page); got != "https://cloud.mongodb.com/api/public/v1.0/groups/1/agents?pageNum=2" {
+		t.Errorf("nextPageURL returned %q", got)
+	}
+
+	if got := nextPageURL(&Agents{}); got != "" {
+		t.Errorf("nextPageURL returned %q, want empty string", got)
+	}
+}
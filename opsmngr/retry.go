@@ -0,0 +1,220 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type retryContextKey struct{}
+
+// WithRetryable marks ctx as safe to retry a non-idempotent request (POST,
+// PATCH) even though its HTTP method isn't normally considered idempotent.
+// Use it when the caller knows the specific request is safe to resend, such
+// as an Automation config push that can be repeated after a transient error.
+func WithRetryable(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryContextKey{}, true)
+}
+
+func isRetryableContext(ctx context.Context) bool {
+	v, _ := ctx.Value(retryContextKey{}).(bool)
+	return v
+}
+
+type retryAttemptsContextKey struct{}
+
+// WithRetryAttempts returns a copy of ctx carrying counter, which the retry
+// transport installed by SetRetry increments once per attempt it makes for
+// the associated request. Unlike OnRequestCompleted, counter is updated on
+// every attempt, including when retries are exhausted on a connection error
+// and Client.Do returns before OnRequestCompleted ever runs.
+func WithRetryAttempts(ctx context.Context, counter *int) context.Context {
+	return context.WithValue(ctx, retryAttemptsContextKey{}, counter)
+}
+
+func retryAttemptsCounter(ctx context.Context) *int {
+	counter, _ := ctx.Value(retryAttemptsContextKey{}).(*int)
+	return counter
+}
+
+type retryAttemptsResultKey struct{}
+
+// RetryAttempts reports how many attempts the retry transport installed by
+// SetRetry made for req, and whether that information is available. It reads
+// a value the transport records on req's context once RoundTrip returns, so
+// it can be called from an OnRequestCompleted callback, which receives the
+// same *http.Request. Unlike WithRetryAttempts, it doesn't require the
+// caller to set anything up in advance, but it isn't available when
+// retries are exhausted on a connection error, since Client.Do returns
+// before OnRequestCompleted ever runs.
+func RetryAttempts(req *http.Request) (int, bool) {
+	attempts, ok := req.Context().Value(retryAttemptsResultKey{}).(int)
+	return attempts, ok
+}
+
+// retryTransport is an http.RoundTripper that retries requests on connection
+// errors, 429, and 502/503/504 responses, using the Retry-After header when
+// present and a full-jitter exponential backoff otherwise.
+type retryTransport struct {
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	base        http.RoundTripper
+}
+
+func newRetryTransport(maxAttempts int, minBackoff, maxBackoff time.Duration, base http.RoundTripper) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &retryTransport{
+		maxAttempts: maxAttempts,
+		minBackoff:  minBackoff,
+		maxBackoff:  maxBackoff,
+		base:        base,
+	}
+}
+
+// SetRetry is a client option that retries failed requests with backoff.
+// Connection errors, 429, and 502/503/504 responses are retried until
+// maxAttempts attempts in all have been made, so a maxAttempts of 1 disables
+// retries. POST and PATCH requests are never retried unless their
+// context was marked with WithRetryable, since they aren't guaranteed to be
+// idempotent. The Retry-After header is honored when the server sends one;
+// otherwise the delay is a full-jitter exponential backoff bounded by
+// minBackoff and maxBackoff.
+func SetRetry(maxAttempts int, minBackoff, maxBackoff time.Duration) ClientOpt {
+	return func(c *Client) error {
+		if maxAttempts < 1 {
+			return errors.New("maxAttempts must be at least 1")
+		}
+		c.client.Transport = newRetryTransport(maxAttempts, minBackoff, maxBackoff, c.client.Transport)
+		return nil
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	retryable := isIdempotentMethod(req.Method) || isRetryableContext(req.Context())
+	counter := retryAttemptsCounter(req.Context())
+
+	var resp *http.Response
+	var err error
+	attempt := 0
+
+	for {
+		attempted, cloneErr := cloneRequest(req)
+		if cloneErr != nil {
+			return nil, cloneErr
+		}
+
+		resp, err = t.base.RoundTrip(attempted)
+		attempt++
+		if counter != nil {
+			*counter = attempt
+		}
+
+		if !retryable || attempt >= t.maxAttempts || !shouldRetry(resp, err) {
+			break
+		}
+
+		wait := retryDelay(resp, t.minBackoff, t.maxBackoff, attempt-1)
+		if resp != nil {
+			_ = resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+
+	*req = *req.WithContext(context.WithValue(req.Context(), retryAttemptsResultKey{}, attempt))
+
+	return resp, err
+}
+
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay honors a Retry-After header (seconds or HTTP-date form) if
+// present, otherwise it computes a full-jitter exponential backoff:
+// rand(0, min(maxBackoff, minBackoff * 2^attempt)).
+func retryDelay(resp *http.Response, minBackoff, maxBackoff time.Duration, attempt int) time.Duration {
+	if d, ok := retryAfter(resp); ok {
+		return d
+	}
+
+	backoff := minBackoff
+	for i := 0; i < attempt; i++ {
+		backoff *= 2
+		if backoff <= 0 || backoff > maxBackoff {
+			backoff = maxBackoff
+			break
+		}
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(backoff) + 1)) //nolint:gosec // jitter does not need to be cryptographically random
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,196 @@
+// Copyright 2021 MongoDB Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opsmngr
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport_RetriesOnServiceUnavailable(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	client, err := New(&http.Client{}, SetBaseURL(server.URL+"/"), SetRetry(5, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodGet, "groups", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var attempts int
+	var v map[string]interface{}
+	if _, err := client.Do(WithRetryAttempts(ctx, &attempts), req, &v); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if requests != 3 {
+		t.Fatalf("expected 3 attempts, got %d", requests)
+	}
+	if attempts != 3 {
+		t.Errorf("expected the retry attempts counter to read 3, got %d", attempts)
+	}
+}
+
+func TestRetryTransport_SurfacesAttemptsViaOnRequestCompleted(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	client, err := New(&http.Client{}, SetBaseURL(server.URL+"/"), SetRetry(5, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	var attempts int
+	var ok bool
+	client.OnRequestCompleted(func(req *http.Request, resp *http.Response) {
+		attempts, ok = RetryAttempts(req)
+	})
+
+	req, err := client.NewRequest(ctx, http.MethodGet, "groups", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var v map[string]interface{}
+	if _, err := client.Do(ctx, req, &v); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if !ok {
+		t.Fatal("expected RetryAttempts to report a value inside OnRequestCompleted")
+	}
+	if attempts != 3 {
+		t.Errorf("expected RetryAttempts to read 3, got %d", attempts)
+	}
+}
+
+func TestRetryTransport_SurfacesAttemptsOnConnectionError(t *testing.T) {
+	failing := &erroringRoundTripper{}
+
+	client, err := New(&http.Client{Transport: failing}, SetBaseURL("https://example.invalid/"), SetRetry(3, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodGet, "groups", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var attempts int
+	// Client.Do returns before c.onRequestCompleted runs when the underlying
+	// transport never produces a response, so WithRetryAttempts is the only
+	// way to observe how many attempts were made in this case.
+	if _, err := client.Do(WithRetryAttempts(ctx, &attempts), req, nil); err == nil {
+		t.Fatal("expected an error from the failing transport")
+	}
+
+	if failing.calls != 3 {
+		t.Fatalf("expected the transport to be invoked 3 times, got %d", failing.calls)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected the retry attempts counter to read 3 even on a connection error, got %d", attempts)
+	}
+}
+
+type erroringRoundTripper struct {
+	calls int
+}
+
+func (e *erroringRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	e.calls++
+	return nil, errors.New("connection refused")
+}
+
+func TestRetryTransport_DoesNotRetryPostByDefault(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := New(&http.Client{}, SetBaseURL(server.URL+"/"), SetRetry(5, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodPost, "groups", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	_, _ = client.Do(ctx, req, nil)
+
+	if requests != 1 {
+		t.Fatalf("expected a non-idempotent request not to be retried, got %d attempts", requests)
+	}
+}
+
+func TestRetryTransport_RetriesPostWhenMarkedRetryable(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"ok":true}`)
+	}))
+	defer server.Close()
+
+	client, err := New(&http.Client{}, SetBaseURL(server.URL+"/"), SetRetry(5, time.Millisecond, 10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	req, err := client.NewRequest(ctx, http.MethodPost, "groups", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+
+	var v map[string]interface{}
+	if _, err := client.Do(WithRetryable(ctx), req, &v); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected the retryable POST to be retried, got %d attempts", requests)
+	}
+}